@@ -0,0 +1,47 @@
+package wlog
+
+import (
+	"runtime"
+	"strconv"
+)
+
+// callerSkipDepth is the number of stack frames between the
+// runtime.Caller call in callerString and the user's original log call.
+// It accounts for wlog's own call chain: callerString -> Format ->
+// Logger.write -> Event.log -> the Logger/Event logging method (Info,
+// Debug, ...) that the user actually called.
+const callerSkipDepth = 5
+
+// CallerMarshalFunc renders the call site captured by Logger.WithCaller
+// into the string emitted as the "caller" field/column. Users can
+// override it, e.g. to strip a module prefix or emit "pkg.Func:line"
+// instead of the default "file:line".
+var CallerMarshalFunc = func(pc uintptr, file string, line int) string {
+	return shortFile(file) + ":" + strconv.Itoa(line)
+}
+
+// WithCaller enables caller capture on l: every subsequent log call will
+// record the file, line and function of its call site, skip frames
+// above callerSkipDepth's baseline. skip is typically 0; pass a higher
+// value when logging is wrapped by helper functions of your own.
+func (l *Logger) WithCaller(skip int) *Logger {
+	l.caller = true
+	l.callerSkip = skip
+
+	return l
+}
+
+// callerString returns the formatted caller for the current entry, and
+// false if l wasn't configured with WithCaller.
+func (l *Logger) callerString() (string, bool) {
+	if !l.caller {
+		return "", false
+	}
+
+	pc, file, line, ok := runtime.Caller(callerSkipDepth + l.callerSkip)
+	if !ok {
+		return "", false
+	}
+
+	return CallerMarshalFunc(pc, file, line), true
+}
@@ -0,0 +1,72 @@
+package wlog
+
+import (
+	"bytes"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestLoggerCallerStringDisabledByDefault(t *testing.T) {
+	l := &Logger{}
+
+	if _, ok := l.callerString(); ok {
+		t.Error("callerString should report false when WithCaller was never called")
+	}
+}
+
+// TestLoggerWithCallerPinsRealCallSite drives a real Info call through
+// the full chain (Logger.Info -> Event.log -> Logger.write ->
+// Formatter.Format -> callerString) and asserts the recorded caller is
+// the exact line below, not some fixed frame inside wlog itself. This
+// is the case callerSkipDepth exists to get right.
+func TestLoggerWithCallerPinsRealCallSite(t *testing.T) {
+	var buf bytes.Buffer
+	l := New(&buf).WithCaller(0)
+
+	l.Info("hello") // <-- wantLine must match this line number
+	wantLine := 27
+
+	got := buf.String()
+	wantSuffix := "caller_test.go:" + strconv.Itoa(wantLine)
+	if !strings.Contains(got, wantSuffix) {
+		t.Errorf("Format output %q does not contain caller %q", got, wantSuffix)
+	}
+}
+
+// TestLoggerWithCallerPinsRealCallSiteChained is the chained-event-builder
+// counterpart to TestLoggerWithCallerPinsRealCallSite: it drives
+// l.Str(...).Info(msg) instead of the bare l.Info(msg) form, since that
+// path has one extra frame (the Str builder call) that callerSkipDepth
+// must still skip past correctly.
+func TestLoggerWithCallerPinsRealCallSiteChained(t *testing.T) {
+	var buf bytes.Buffer
+	l := New(&buf).WithCaller(0)
+
+	l.Str("k", "v").Info("hello") // <-- wantLine must match this line number
+	wantLine := 46
+
+	got := buf.String()
+	wantSuffix := "caller_test.go:" + strconv.Itoa(wantLine)
+	if !strings.Contains(got, wantSuffix) {
+		t.Errorf("Format output %q does not contain caller %q", got, wantSuffix)
+	}
+}
+
+func TestCallerMarshalFuncOverride(t *testing.T) {
+	orig := CallerMarshalFunc
+	defer func() { CallerMarshalFunc = orig }()
+
+	CallerMarshalFunc = func(pc uintptr, file string, line int) string {
+		return "overridden"
+	}
+
+	var buf bytes.Buffer
+	l := New(&buf).WithCaller(0)
+
+	l.Info("hello")
+
+	if !strings.Contains(buf.String(), "overridden") {
+		t.Errorf("Format output %q does not contain overridden caller marker", buf.String())
+	}
+}
@@ -0,0 +1,204 @@
+//go:build binary_log
+
+package wlog
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"time"
+)
+
+// CBORFormatter encodes each log entry as a self-describing CBOR map,
+// suitable for compact machine-consumable binary logs. It is only
+// compiled in when the "binary_log" build tag is set, so consumers of
+// wlog don't pay for a CBOR encoder unless they ask for it.
+type CBORFormatter struct{}
+
+// Format writes the entry as a CBOR map with "timestamp", "level", "msg"
+// and any user fields from e.
+func (c CBORFormatter) Format(w io.Writer, l *Logger, e *Event, msg string, entryTime time.Time) error {
+	fields := e.Fields()
+
+	keys := make([]string, 0, len(fields))
+	for k := range fields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	enc := cborEncoder{w: w}
+
+	if err := enc.writeMapHeader(uint64(3 + len(keys))); err != nil {
+		return fmt.Errorf("failed to marshal fields to CBOR, %v", err)
+	}
+
+	if err := enc.writeTextString("timestamp"); err != nil {
+		return err
+	}
+	if err := enc.writeEpochTime(entryTime); err != nil {
+		return err
+	}
+
+	if err := enc.writeTextString("level"); err != nil {
+		return err
+	}
+	if err := enc.writeTextString(e.Level().String()); err != nil {
+		return err
+	}
+
+	if err := enc.writeTextString("msg"); err != nil {
+		return err
+	}
+	if err := enc.writeTextString(msg); err != nil {
+		return err
+	}
+
+	for _, k := range keys {
+		if err := enc.writeTextString(k); err != nil {
+			return err
+		}
+		if err := enc.writeValue(fields[k]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// cborEncoder writes a minimal subset of RFC 8949 CBOR sufficient for
+// wlog entries: maps, text strings, unsigned/negative integers, floats,
+// booleans and tag 1 (epoch-based time).
+type cborEncoder struct {
+	w io.Writer
+}
+
+const (
+	cborMajorUnsigned = 0 << 5
+	cborMajorNegative = 1 << 5
+	cborMajorText     = 3 << 5
+	cborMajorArray    = 4 << 5
+	cborMajorMap      = 5 << 5
+	cborMajorTag      = 6 << 5
+	cborMajorSimple   = 7 << 5
+)
+
+func (e *cborEncoder) writeHead(major byte, value uint64) error {
+	switch {
+	case value < 24:
+		_, err := e.w.Write([]byte{major | byte(value)})
+		return err
+	case value <= 0xff:
+		_, err := e.w.Write([]byte{major | 24, byte(value)})
+		return err
+	case value <= 0xffff:
+		_, err := e.w.Write([]byte{major | 25, byte(value >> 8), byte(value)})
+		return err
+	case value <= 0xffffffff:
+		_, err := e.w.Write([]byte{major | 26, byte(value >> 24), byte(value >> 16), byte(value >> 8), byte(value)})
+		return err
+	default:
+		buf := []byte{major | 27, 0, 0, 0, 0, 0, 0, 0, 0}
+		for i := 0; i < 8; i++ {
+			buf[8-i] = byte(value >> (8 * i))
+		}
+		_, err := e.w.Write(buf)
+		return err
+	}
+}
+
+func (e *cborEncoder) writeMapHeader(n uint64) error {
+	return e.writeHead(cborMajorMap, n)
+}
+
+func (e *cborEncoder) writeTextString(s string) error {
+	if err := e.writeHead(cborMajorText, uint64(len(s))); err != nil {
+		return err
+	}
+	_, err := io.WriteString(e.w, s)
+	return err
+}
+
+func (e *cborEncoder) writeUint(v uint64) error {
+	return e.writeHead(cborMajorUnsigned, v)
+}
+
+func (e *cborEncoder) writeInt(v int64) error {
+	if v >= 0 {
+		return e.writeUint(uint64(v))
+	}
+	return e.writeHead(cborMajorNegative, uint64(-v)-1)
+}
+
+func (e *cborEncoder) writeFloat64(v float64) error {
+	bits := math.Float64bits(v)
+	if _, err := e.w.Write([]byte{cborMajorSimple | 27}); err != nil {
+		return err
+	}
+	buf := make([]byte, 8)
+	for i := 0; i < 8; i++ {
+		buf[7-i] = byte(bits >> (8 * i))
+	}
+	_, err := e.w.Write(buf)
+	return err
+}
+
+func (e *cborEncoder) writeBool(v bool) error {
+	b := byte(cborMajorSimple | 20)
+	if v {
+		b = cborMajorSimple | 21
+	}
+	_, err := e.w.Write([]byte{b})
+	return err
+}
+
+// writeEpochTime encodes t as CBOR tag 1 (epoch-based date/time) followed
+// by a float64 holding fractional seconds since the Unix epoch.
+func (e *cborEncoder) writeEpochTime(t time.Time) error {
+	if err := e.writeHead(cborMajorTag, 1); err != nil {
+		return err
+	}
+	seconds := float64(t.UnixNano()) / float64(time.Second)
+	return e.writeFloat64(seconds)
+}
+
+func (e *cborEncoder) writeValue(v interface{}) error {
+	switch val := v.(type) {
+	case string:
+		return e.writeTextString(val)
+	case bool:
+		return e.writeBool(val)
+	case int:
+		return e.writeInt(int64(val))
+	case int64:
+		return e.writeInt(val)
+	case uint64:
+		return e.writeUint(val)
+	case float64:
+		return e.writeFloat64(val)
+	case time.Time:
+		return e.writeEpochTime(val)
+	case []string:
+		if err := e.writeHead(cborMajorArray, uint64(len(val))); err != nil {
+			return err
+		}
+		for _, s := range val {
+			if err := e.writeTextString(s); err != nil {
+				return err
+			}
+		}
+		return nil
+	case []int:
+		if err := e.writeHead(cborMajorArray, uint64(len(val))); err != nil {
+			return err
+		}
+		for _, n := range val {
+			if err := e.writeInt(int64(n)); err != nil {
+				return err
+			}
+		}
+		return nil
+	default:
+		return e.writeTextString(fmt.Sprintf("%v", val))
+	}
+}
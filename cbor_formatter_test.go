@@ -0,0 +1,153 @@
+//go:build binary_log
+
+package wlog
+
+import (
+	"bytes"
+	"testing"
+	"time"
+)
+
+// decodeCBORMap is a tiny decoder covering what the tests below feed
+// through CBORFormatter (short text strings, small unsigned integers,
+// arrays of those, and tagged epoch times), used so the test can assert
+// on values without depending on a full CBOR library.
+func decodeCBORMap(t *testing.T, data []byte) map[string]interface{} {
+	t.Helper()
+
+	r := bytes.NewReader(data)
+	readHead := func() (byte, uint64) {
+		b, err := r.ReadByte()
+		if err != nil {
+			t.Fatalf("reading head: %v", err)
+		}
+		major := b >> 5
+		info := b & 0x1f
+		switch {
+		case info < 24:
+			return major, uint64(info)
+		case info == 24:
+			n, _ := r.ReadByte()
+			return major, uint64(n)
+		case info == 27:
+			buf := make([]byte, 8)
+			r.Read(buf)
+			var v uint64
+			for _, b := range buf {
+				v = v<<8 | uint64(b)
+			}
+			return major, v
+		}
+		t.Fatalf("unsupported additional info %d", info)
+		return 0, 0
+	}
+
+	readString := func(n uint64) string {
+		buf := make([]byte, n)
+		r.Read(buf)
+		return string(buf)
+	}
+
+	major, n := readHead()
+	if major != 5 {
+		t.Fatalf("expected a CBOR map, got major type %d", major)
+	}
+
+	out := make(map[string]interface{}, n)
+	for i := uint64(0); i < n; i++ {
+		kMajor, kLen := readHead()
+		if kMajor != 3 {
+			t.Fatalf("expected a text string key, got major type %d", kMajor)
+		}
+		key := readString(kLen)
+
+		vMajor, vArg := readHead()
+		switch vMajor {
+		case 0:
+			out[key] = vArg
+		case 3:
+			out[key] = readString(vArg)
+		case 4: // array
+			items := make([]interface{}, 0, vArg)
+			for j := uint64(0); j < vArg; j++ {
+				iMajor, iArg := readHead()
+				switch iMajor {
+				case 0:
+					items = append(items, iArg)
+				case 3:
+					items = append(items, readString(iArg))
+				default:
+					t.Fatalf("unsupported array element major type %d for key %q", iMajor, key)
+				}
+			}
+			out[key] = items
+		case 6: // tag, e.g. epoch time; skip the tag and read the float payload
+			_, _ = readHead()
+		default:
+			t.Fatalf("unsupported value major type %d for key %q", vMajor, key)
+		}
+	}
+
+	return out
+}
+
+func TestCBORFormatterFormat(t *testing.T) {
+	var buf bytes.Buffer
+
+	f := CBORFormatter{}
+	e := &Event{fields: map[string]interface{}{"user": "alice"}, level: Nfo}
+
+	if err := f.Format(&buf, &Logger{}, e, "hello", time.Now()); err != nil {
+		t.Fatalf("Format returned error: %v", err)
+	}
+
+	got := decodeCBORMap(t, buf.Bytes())
+
+	if got["msg"] != "hello" {
+		t.Errorf("msg = %v, want %q", got["msg"], "hello")
+	}
+	if got["level"] != "NFO" {
+		t.Errorf("level = %v, want %q", got["level"], "NFO")
+	}
+	if got["user"] != "alice" {
+		t.Errorf("user = %v, want %q", got["user"], "alice")
+	}
+}
+
+func TestCBORFormatterEncodesSliceFieldsAsArrays(t *testing.T) {
+	var buf bytes.Buffer
+
+	f := CBORFormatter{}
+	e := &Event{fields: map[string]interface{}{
+		"tags":  []string{"a", "b"},
+		"codes": []int{1, 2, 3},
+	}, level: Nfo}
+
+	if err := f.Format(&buf, &Logger{}, e, "hello", time.Now()); err != nil {
+		t.Fatalf("Format returned error: %v", err)
+	}
+
+	got := decodeCBORMap(t, buf.Bytes())
+
+	wantTags := []interface{}{"a", "b"}
+	if gotTags, ok := got["tags"].([]interface{}); !ok || !equalInterfaceSlices(gotTags, wantTags) {
+		t.Errorf("tags = %v, want %v (array, not a flattened string)", got["tags"], wantTags)
+	}
+
+	wantCodes := []interface{}{uint64(1), uint64(2), uint64(3)}
+	if gotCodes, ok := got["codes"].([]interface{}); !ok || !equalInterfaceSlices(gotCodes, wantCodes) {
+		t.Errorf("codes = %v, want %v (array, not a flattened string)", got["codes"], wantCodes)
+	}
+}
+
+func equalInterfaceSlices(a, b []interface{}) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,162 @@
+//go:build binary_log
+
+// Command wlog-cbor2json streams CBOR log records written by
+// wlog.CBORFormatter from stdin and emits newline-delimited JSON on
+// stdout, for human inspection of binary log files.
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"time"
+)
+
+func main() {
+	if err := run(os.Stdin, os.Stdout); err != nil {
+		fmt.Fprintf(os.Stderr, "wlog-cbor2json: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(in io.Reader, out io.Writer) error {
+	r := bufio.NewReader(in)
+	enc := json.NewEncoder(out)
+
+	for {
+		v, err := decodeValue(r)
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		if err := enc.Encode(v); err != nil {
+			return fmt.Errorf("failed to encode JSON: %w", err)
+		}
+	}
+}
+
+// decodeValue decodes a single CBOR data item, covering the subset
+// produced by wlog.CBORFormatter: maps, arrays, text strings,
+// unsigned/negative integers, float64, booleans and tag 1 (epoch-based
+// time).
+func decodeValue(r *bufio.Reader) (interface{}, error) {
+	major, info, value, err := readHead(r)
+	if err != nil {
+		return nil, err
+	}
+
+	switch major {
+	case 0: // unsigned int
+		return value, nil
+	case 1: // negative int
+		return -1 - int64(value), nil
+	case 3: // text string
+		buf := make([]byte, value)
+		if _, err := io.ReadFull(r, buf); err != nil {
+			return nil, fmt.Errorf("reading text string: %w", err)
+		}
+		return string(buf), nil
+	case 4: // array
+		items := make([]interface{}, 0, value)
+		for i := uint64(0); i < value; i++ {
+			item, err := decodeValue(r)
+			if err != nil {
+				return nil, fmt.Errorf("decoding array element: %w", err)
+			}
+			items = append(items, item)
+		}
+		return items, nil
+	case 5: // map
+		m := make(map[string]interface{}, value)
+		for i := uint64(0); i < value; i++ {
+			k, err := decodeValue(r)
+			if err != nil {
+				return nil, fmt.Errorf("decoding map key: %w", err)
+			}
+			key, ok := k.(string)
+			if !ok {
+				return nil, fmt.Errorf("unsupported non-string map key: %v", k)
+			}
+			v, err := decodeValue(r)
+			if err != nil {
+				return nil, fmt.Errorf("decoding map value for %q: %w", key, err)
+			}
+			m[key] = v
+		}
+		return m, nil
+	case 6: // tagged value
+		inner, err := decodeValue(r)
+		if err != nil {
+			return nil, fmt.Errorf("decoding tagged value: %w", err)
+		}
+		if value == 1 {
+			if seconds, ok := inner.(float64); ok {
+				return time.Unix(0, int64(seconds*float64(time.Second))).UTC().Format(time.RFC3339Nano), nil
+			}
+		}
+		return inner, nil
+	case 7: // simple/float
+		switch info {
+		case 20:
+			return false, nil
+		case 21:
+			return true, nil
+		case 27:
+			return math.Float64frombits(value), nil
+		}
+		return nil, fmt.Errorf("unsupported simple value, additional info %d", info)
+	default:
+		return nil, fmt.Errorf("unsupported CBOR major type %d", major)
+	}
+}
+
+// readHead reads a CBOR initial byte plus its argument and returns the
+// major type, the raw additional-information nibble and the decoded
+// argument value.
+func readHead(r *bufio.Reader) (major byte, info byte, value uint64, err error) {
+	b, err := r.ReadByte()
+	if err != nil {
+		return 0, 0, 0, err
+	}
+
+	major = b >> 5
+	info = b & 0x1f
+
+	switch {
+	case info < 24:
+		return major, info, uint64(info), nil
+	case info == 24:
+		n, err := r.ReadByte()
+		return major, info, uint64(n), err
+	case info == 25:
+		buf := make([]byte, 2)
+		if _, err := io.ReadFull(r, buf); err != nil {
+			return 0, 0, 0, err
+		}
+		return major, info, uint64(buf[0])<<8 | uint64(buf[1]), nil
+	case info == 26:
+		buf := make([]byte, 4)
+		if _, err := io.ReadFull(r, buf); err != nil {
+			return 0, 0, 0, err
+		}
+		return major, info, uint64(buf[0])<<24 | uint64(buf[1])<<16 | uint64(buf[2])<<8 | uint64(buf[3]), nil
+	case info == 27:
+		buf := make([]byte, 8)
+		if _, err := io.ReadFull(r, buf); err != nil {
+			return 0, 0, 0, err
+		}
+		var v uint64
+		for _, b := range buf {
+			v = v<<8 | uint64(b)
+		}
+		return major, info, v, nil
+	default:
+		return 0, 0, 0, fmt.Errorf("unsupported additional information %d", info)
+	}
+}
@@ -0,0 +1,164 @@
+package wlog
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"time"
+)
+
+// ANSI color codes used by ConsoleFormatter to highlight log levels.
+const (
+	colorReset   = "\033[0m"
+	colorCyan    = "\033[36m"
+	colorGreen   = "\033[32m"
+	colorYellow  = "\033[33m"
+	colorRed     = "\033[31m"
+	colorMagenta = "\033[35m"
+	colorBold    = "\033[1m"
+)
+
+// defaultPartsOrder is the column order used when ConsoleFormatter.PartsOrder
+// is left unset.
+var defaultPartsOrder = []string{"time", "level", "msg", "fields"}
+
+// ConsoleFormatter used to output human-readable, colorized logs suitable
+// for interactive terminals. Colors are disabled automatically when the
+// destination is not a TTY or when the NO_COLOR environment variable is
+// set.
+type ConsoleFormatter struct {
+	// NoColor forces colors off regardless of TTY detection.
+	NoColor bool
+
+	// PartsOrder controls which columns are rendered and in what order.
+	// Recognized parts are "time", "level", "msg" and "fields". Defaults
+	// to defaultPartsOrder when nil.
+	PartsOrder []string
+
+	// FormatTimestamp renders the entry time. Defaults to RFC3339-ish
+	// "2006-01-02 15:04:05".
+	FormatTimestamp func(t time.Time) string
+
+	// FormatLevel renders the colorized level column.
+	FormatLevel func(level string, noColor bool) string
+
+	// FormatFieldName renders a field key, e.g. "key=".
+	FormatFieldName func(name string) string
+
+	// FormatFieldValue renders a field value.
+	FormatFieldValue func(value interface{}) string
+}
+
+// Implements Formatter.Format
+func (c ConsoleFormatter) Format(w io.Writer, l *Logger, e *Event, msg string, entryTime time.Time) error {
+	order := c.PartsOrder
+	if order == nil {
+		order = defaultPartsOrder
+	}
+
+	noColor := c.NoColor || !isTerminalWriter(w) || os.Getenv("NO_COLOR") != ""
+
+	for i, part := range order {
+		if i > 0 {
+			writeString(w, " ")
+		}
+
+		switch part {
+		case "time":
+			writeString(w, c.formatTimestamp(entryTime))
+		case "level":
+			writeString(w, c.formatLevel(e.Level().String(), noColor))
+		case "msg":
+			writeString(w, msg)
+		case "fields":
+			c.writeFields(w, e.Fields())
+		}
+	}
+
+	writeString(w, "\n")
+
+	return nil
+}
+
+func (c ConsoleFormatter) formatTimestamp(t time.Time) string {
+	if c.FormatTimestamp != nil {
+		return c.FormatTimestamp(t)
+	}
+	return t.Format("2006-01-02 15:04:05")
+}
+
+func (c ConsoleFormatter) formatLevel(level string, noColor bool) string {
+	if c.FormatLevel != nil {
+		return c.FormatLevel(level, noColor)
+	}
+
+	if noColor {
+		return level
+	}
+
+	var color string
+	switch level {
+	case Dbg.String():
+		color = colorCyan
+	case Nfo.String():
+		color = colorGreen
+	case Wrn.String():
+		color = colorYellow
+	case Err.String():
+		color = colorRed
+	case Ftl.String():
+		color = colorMagenta + colorBold
+	default:
+		return level
+	}
+
+	return color + level + colorReset
+}
+
+func (c ConsoleFormatter) writeFields(w io.Writer, fields map[string]interface{}) {
+	keys := make([]string, 0, len(fields))
+	for k := range fields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for i, k := range keys {
+		if i > 0 {
+			writeString(w, " ")
+		}
+		writeString(w, c.formatFieldName(k))
+		writeString(w, c.formatFieldValue(fields[k]))
+	}
+}
+
+func (c ConsoleFormatter) formatFieldName(name string) string {
+	if c.FormatFieldName != nil {
+		return c.FormatFieldName(name)
+	}
+	return name + "="
+}
+
+func (c ConsoleFormatter) formatFieldValue(value interface{}) string {
+	if c.FormatFieldValue != nil {
+		return c.FormatFieldValue(value)
+	}
+	return fmt.Sprintf("%v", value)
+}
+
+// isTerminalWriter reports whether w refers to a character device such as
+// a terminal. Non-*os.File writers (buffers, network connections, ...)
+// are treated as non-TTY so colors are disabled by default.
+func isTerminalWriter(w io.Writer) bool {
+	f, ok := w.(*os.File)
+	if !ok {
+		return false
+	}
+
+	fi, err := f.Stat()
+	if err != nil {
+		return false
+	}
+
+	return (fi.Mode() & os.ModeCharDevice) != 0
+}
@@ -0,0 +1,41 @@
+package wlog
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestConsoleFormatterFormat(t *testing.T) {
+	var buf bytes.Buffer
+
+	f := ConsoleFormatter{NoColor: true}
+	e := &Event{fields: map[string]interface{}{"user": "alice"}, level: Nfo}
+
+	if err := f.Format(&buf, &Logger{}, e, "hello", time.Date(2026, 7, 26, 1, 2, 3, 0, time.UTC)); err != nil {
+		t.Fatalf("Format returned error: %v", err)
+	}
+
+	got := buf.String()
+	for _, want := range []string{"2026-07-26", "NFO", "hello", "user=alice"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("Format output %q missing %q", got, want)
+		}
+	}
+}
+
+func TestConsoleFormatterNoColorStripsANSI(t *testing.T) {
+	var buf bytes.Buffer
+
+	f := ConsoleFormatter{NoColor: true}
+	e := &Event{fields: map[string]interface{}{}, level: Err}
+
+	if err := f.Format(&buf, &Logger{}, e, "boom", time.Now()); err != nil {
+		t.Fatalf("Format returned error: %v", err)
+	}
+
+	if strings.Contains(buf.String(), "\033[") {
+		t.Errorf("Format output contains ANSI escape codes with NoColor set: %q", buf.String())
+	}
+}
@@ -0,0 +1,187 @@
+package wlog
+
+import (
+	"fmt"
+	"os"
+	"time"
+)
+
+// Event is a per-call buffer of log fields built by the typed field
+// helpers below (Str, Int, ...). Every logging call (Info, Debug, ...)
+// starts a fresh Event, so concurrent goroutines sharing a *Logger never
+// mutate each other's fields - unlike the old Logger.fields map, which
+// every caller wrote into directly.
+type Event struct {
+	logger *Logger
+	level  Level
+	fields map[string]interface{}
+}
+
+func newEvent(l *Logger) *Event {
+	return &Event{logger: l, fields: make(map[string]interface{})}
+}
+
+// Level returns the level this event will be logged at.
+func (e *Event) Level() Level {
+	return e.level
+}
+
+// Fields returns the event's accumulated fields.
+func (e *Event) Fields() map[string]interface{} {
+	return e.fields
+}
+
+// Str adds a string field and returns e for chaining.
+func (e *Event) Str(key string, val string) *Event {
+	e.fields[key] = val
+	return e
+}
+
+// Int adds an int field and returns e for chaining.
+func (e *Event) Int(key string, val int) *Event {
+	e.fields[key] = val
+	return e
+}
+
+// Float64 adds a float64 field and returns e for chaining.
+func (e *Event) Float64(key string, val float64) *Event {
+	e.fields[key] = val
+	return e
+}
+
+// Bool adds a bool field and returns e for chaining.
+func (e *Event) Bool(key string, val bool) *Event {
+	e.fields[key] = val
+	return e
+}
+
+// Dur adds a time.Duration field and returns e for chaining.
+func (e *Event) Dur(key string, val time.Duration) *Event {
+	e.fields[key] = val
+	return e
+}
+
+// Time adds a time.Time field and returns e for chaining.
+func (e *Event) Time(key string, val time.Time) *Event {
+	e.fields[key] = val
+	return e
+}
+
+// Err adds an error field under the "error" key and returns e for
+// chaining. A nil err is still recorded, matching Str/Int's behavior of
+// recording whatever is passed.
+func (e *Event) Err(err error) *Event {
+	if err == nil {
+		e.fields["error"] = nil
+		return e
+	}
+	e.fields["error"] = err.Error()
+	return e
+}
+
+// Strs adds a []string field and returns e for chaining.
+func (e *Event) Strs(key string, vals []string) *Event {
+	e.fields[key] = vals
+	return e
+}
+
+// Ints adds a []int field and returns e for chaining.
+func (e *Event) Ints(key string, vals []int) *Event {
+	e.fields[key] = vals
+	return e
+}
+
+// Errs adds a []error field, rendered as their error strings, and
+// returns e for chaining.
+func (e *Event) Errs(key string, errs []error) *Event {
+	msgs := make([]string, len(errs))
+	for i, err := range errs {
+		if err != nil {
+			msgs[i] = err.Error()
+		}
+	}
+	e.fields[key] = msgs
+	return e
+}
+
+// Debug logs msg at the Dbg level using e's accumulated fields.
+func (e *Event) Debug(msg string) { e.log(Dbg, msg) }
+
+// Info logs msg at the Nfo level using e's accumulated fields.
+func (e *Event) Info(msg string) { e.log(Nfo, msg) }
+
+// Warn logs msg at the Wrn level using e's accumulated fields.
+func (e *Event) Warn(msg string) { e.log(Wrn, msg) }
+
+// Error logs msg at the Err level using e's accumulated fields.
+func (e *Event) Error(msg string) { e.log(Err, msg) }
+
+// Fatal logs msg at the Ftl level using e's accumulated fields.
+func (e *Event) Fatal(msg string) { e.log(Ftl, msg) }
+
+func (e *Event) log(level Level, msg string) {
+	e.level = level
+	e.logger.write(e, msg)
+}
+
+// write renders e through the logger's configured formatter and writes
+// the result to its output. Formatting errors are reported to stderr,
+// matching writeString's existing best-effort behavior elsewhere in the
+// package.
+func (l *Logger) write(e *Event, msg string) {
+	if err := l.formatter.Format(l.out, l, e, msg, time.Now()); err != nil {
+		fmt.Fprintf(os.Stderr, "could not format entry log, err: %s", err)
+	}
+}
+
+// Str starts a fresh Event with a single string field. It is shorthand
+// for newEvent(l).Str(key, val), letting callers chain straight off the
+// Logger: l.Str("key", "val").Info("message").
+func (l *Logger) Str(key string, val string) *Event { return newEvent(l).Str(key, val) }
+
+// Int starts a fresh Event with a single int field.
+func (l *Logger) Int(key string, val int) *Event { return newEvent(l).Int(key, val) }
+
+// Float64 starts a fresh Event with a single float64 field.
+func (l *Logger) Float64(key string, val float64) *Event { return newEvent(l).Float64(key, val) }
+
+// Bool starts a fresh Event with a single bool field.
+func (l *Logger) Bool(key string, val bool) *Event { return newEvent(l).Bool(key, val) }
+
+// Dur starts a fresh Event with a single time.Duration field.
+func (l *Logger) Dur(key string, val time.Duration) *Event { return newEvent(l).Dur(key, val) }
+
+// Time starts a fresh Event with a single time.Time field.
+func (l *Logger) Time(key string, val time.Time) *Event { return newEvent(l).Time(key, val) }
+
+// Err starts a fresh Event with a single error field.
+func (l *Logger) Err(err error) *Event { return newEvent(l).Err(err) }
+
+// Strs starts a fresh Event with a single []string field.
+func (l *Logger) Strs(key string, vals []string) *Event { return newEvent(l).Strs(key, vals) }
+
+// Ints starts a fresh Event with a single []int field.
+func (l *Logger) Ints(key string, vals []int) *Event { return newEvent(l).Ints(key, vals) }
+
+// Errs starts a fresh Event with a single []error field.
+func (l *Logger) Errs(key string, errs []error) *Event { return newEvent(l).Errs(key, errs) }
+
+// Debug logs msg at the Dbg level with no fields.
+//
+// This calls e.log directly, rather than through Event.Debug, so that
+// it sits at the same stack depth as the chained l.Str(...).Debug(msg)
+// form - both callerSkipDepth and patternSkipDepth assume exactly one
+// frame between here and Logger.write.
+func (l *Logger) Debug(msg string) { newEvent(l).log(Dbg, msg) }
+
+// Info logs msg at the Nfo level with no fields.
+func (l *Logger) Info(msg string) { newEvent(l).log(Nfo, msg) }
+
+// Warn logs msg at the Wrn level with no fields.
+func (l *Logger) Warn(msg string) { newEvent(l).log(Wrn, msg) }
+
+// Error logs msg at the Err level with no fields.
+func (l *Logger) Error(msg string) { newEvent(l).log(Err, msg) }
+
+// Fatal logs msg at the Ftl level with no fields.
+func (l *Logger) Fatal(msg string) { newEvent(l).log(Ftl, msg) }
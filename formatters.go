@@ -10,23 +10,29 @@ import (
 
 // Formatter is a base interface for output formatters, it has
 // one method called Format which will be called when outputting
-// the write entry
+// the write entry. The fields logged for this particular call are
+// carried by e, not by the Logger itself - see Event.
 type Formatter interface {
-	Format(w io.Writer, l *Logger, msg string, entryTime time.Time) error
+	Format(w io.Writer, l *Logger, e *Event, msg string, entryTime time.Time) error
 }
 
 // JSONFormatter used to output logs in JSON format
 type JSONFormatter struct{}
 
 // Implements Formatter.Format
-func (j JSONFormatter) Format(w io.Writer, l *Logger, msg string, entryTime time.Time) error {
-	l.fields["msg"] = msg
-	l.fields["timestamp"] = getTimestamp(entryTime)
-	l.fields["level"] = l.logLevel.String()
+func (j JSONFormatter) Format(w io.Writer, l *Logger, e *Event, msg string, entryTime time.Time) error {
+	fields := e.Fields()
+	fields["msg"] = msg
+	fields["timestamp"] = getTimestamp(entryTime)
+	fields["level"] = e.Level().String()
+
+	if caller, ok := l.callerString(); ok {
+		fields["caller"] = caller
+	}
 
 	encoder := json.NewEncoder(w)
 
-	if err := encoder.Encode(l.fields); err != nil {
+	if err := encoder.Encode(fields); err != nil {
 		return fmt.Errorf("failed to marshal fields to JSON, %v", err)
 	}
 
@@ -37,7 +43,7 @@ func (j JSONFormatter) Format(w io.Writer, l *Logger, msg string, entryTime time
 // formatter when creating a instance of wlog.
 type TextFormatter struct{}
 
-func (t TextFormatter) Format(w io.Writer, l *Logger, msg string, entryTime time.Time) error {
+func (t TextFormatter) Format(w io.Writer, l *Logger, e *Event, msg string, entryTime time.Time) error {
 
 	// Write Date
 	year, month, day := entryTime.Date()
@@ -63,7 +69,7 @@ func (t TextFormatter) Format(w io.Writer, l *Logger, msg string, entryTime time
 
 	// Write log level
 	var level string
-	switch l.logLevel {
+	switch e.Level() {
 	case Dbg:
 		level = "DBG "
 	case Nfo:
@@ -78,6 +84,12 @@ func (t TextFormatter) Format(w io.Writer, l *Logger, msg string, entryTime time
 
 	writeString(w, level)
 
+	// Write caller, if the logger was configured with WithCaller
+	if caller, ok := l.callerString(); ok {
+		writeString(w, caller)
+		writeString(w, " ")
+	}
+
 	// Append log message to buffer
 	writeString(w, msg)
 
@@ -0,0 +1,124 @@
+//go:build linux
+
+package wlog
+
+import (
+	"bytes"
+	"encoding/binary"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestJournaldPriority(t *testing.T) {
+	cases := map[Level]int{
+		Dbg: 7,
+		Nfo: 6,
+		Wrn: 4,
+		Err: 3,
+		Ftl: 2,
+	}
+
+	for level, want := range cases {
+		if got := journaldPriority(level); got != want {
+			t.Errorf("journaldPriority(%v) = %d, want %d", level, got, want)
+		}
+	}
+}
+
+func TestWriteJournaldFieldSingleLine(t *testing.T) {
+	var buf bytes.Buffer
+
+	writeJournaldField(&buf, "MESSAGE", "hello world")
+
+	if got, want := buf.String(), "MESSAGE=hello world\n"; got != want {
+		t.Errorf("writeJournaldField wrote %q, want %q", got, want)
+	}
+}
+
+func TestWriteJournaldFieldMultiLine(t *testing.T) {
+	var buf bytes.Buffer
+
+	value := "line one\nline two"
+	writeJournaldField(&buf, "MESSAGE", value)
+
+	got := buf.Bytes()
+	wantPrefix := []byte("MESSAGE\n")
+	if !bytes.HasPrefix(got, wantPrefix) {
+		t.Fatalf("writeJournaldField output %q does not start with %q", got, wantPrefix)
+	}
+
+	rest := got[len(wantPrefix):]
+	if len(rest) < 8 {
+		t.Fatalf("writeJournaldField output too short for a length-prefixed value: %q", got)
+	}
+
+	gotLen := binary.LittleEndian.Uint64(rest[:8])
+	if int(gotLen) != len(value) {
+		t.Errorf("length prefix = %d, want %d", gotLen, len(value))
+	}
+
+	payload := rest[8:]
+	if got, want := string(payload), value+"\n"; got != want {
+		t.Errorf("payload = %q, want %q", got, want)
+	}
+}
+
+func TestJournaldFieldName(t *testing.T) {
+	cases := map[string]string{
+		"user":       "USER",
+		"request.id": "REQUEST_ID",
+		"user-name":  "USER_NAME",
+		"2fa_code":   "_2FA_CODE",
+	}
+
+	for in, want := range cases {
+		if got := journaldFieldName(in); got != want {
+			t.Errorf("journaldFieldName(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestJournaldFormatterSanitizesFieldNames(t *testing.T) {
+	var buf bytes.Buffer
+
+	f := JournaldFormatter{SyslogIdentifier: "wlogtest"}
+	e := &Event{fields: map[string]interface{}{
+		"request.id": "abc123",
+		"user-name":  "alice",
+	}, level: Nfo}
+
+	if err := f.Format(&buf, &Logger{}, e, "hello", time.Now()); err != nil {
+		t.Fatalf("Format returned error: %v", err)
+	}
+
+	got := buf.String()
+	for _, want := range []string{"REQUEST_ID=abc123\n", "USER_NAME=alice\n"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("Format output %q missing sanitized field %q", got, want)
+		}
+	}
+	for _, badSubstr := range []string{"REQUEST.ID=", "USER-NAME="} {
+		if strings.Contains(got, badSubstr) {
+			t.Errorf("Format output %q contains wire-invalid field name %q", got, badSubstr)
+		}
+	}
+}
+
+func TestJournaldFormatterFormat(t *testing.T) {
+	var buf bytes.Buffer
+
+	f := JournaldFormatter{SyslogIdentifier: "wlogtest"}
+	e := &Event{fields: map[string]interface{}{"user": "alice"}, level: Err}
+
+	if err := f.Format(&buf, &Logger{}, e, "boom", time.Now()); err != nil {
+		t.Fatalf("Format returned error: %v", err)
+	}
+
+	got := buf.String()
+	for _, want := range []string{"MESSAGE=boom\n", "PRIORITY=3\n", "SYSLOG_IDENTIFIER=wlogtest\n", "USER=alice\n"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("Format output %q missing %q", got, want)
+		}
+	}
+}
@@ -0,0 +1,66 @@
+package wlog
+
+import "io"
+
+// Level represents the severity of a log entry.
+type Level int
+
+// Supported severities, from least to most severe.
+const (
+	Dbg Level = iota
+	Nfo
+	Wrn
+	Err
+	Ftl
+)
+
+// String implements fmt.Stringer, rendering the short, fixed-width form
+// used throughout wlog's formatters.
+func (lv Level) String() string {
+	switch lv {
+	case Dbg:
+		return "DBG"
+	case Nfo:
+		return "NFO"
+	case Wrn:
+		return "WRN"
+	case Err:
+		return "ERR"
+	case Ftl:
+		return "FTL"
+	default:
+		return "UNK"
+	}
+}
+
+// Logger is wlog's entry point: it pairs an output destination with a
+// Formatter and holds the (opt-in) caller-capture configuration applied
+// to every entry it produces.
+type Logger struct {
+	out       io.Writer
+	formatter Formatter
+
+	caller     bool
+	callerSkip int
+}
+
+// New creates a Logger that writes to out using the default
+// TextFormatter.
+func New(out io.Writer) *Logger {
+	return &Logger{
+		out:       out,
+		formatter: TextFormatter{},
+	}
+}
+
+// SetFormatter changes the Formatter used to render subsequent entries.
+func (l *Logger) SetFormatter(f Formatter) *Logger {
+	l.formatter = f
+	return l
+}
+
+// SetOutput changes the destination subsequent entries are written to.
+func (l *Logger) SetOutput(out io.Writer) *Logger {
+	l.out = out
+	return l
+}
@@ -0,0 +1,232 @@
+package wlog
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+)
+
+// patternSkipDepth is the number of stack frames between the
+// runtime.Caller call in formatPatternCaller and the user's original log
+// call: formatPatternCaller -> PatternFormatter.Format -> Logger.write ->
+// Event.log -> the Logger/Event logging method (Info, Debug, ...) that
+// the user actually called.
+const patternSkipDepth = 5
+
+// patternDirective renders one compiled segment of a pattern: either a
+// literal string copied verbatim, or a function producing dynamic
+// content for the current entry.
+type patternDirective func(w io.Writer, l *Logger, e *Event, msg string, t time.Time)
+
+// PatternFormatter renders log entries from a format string containing
+// %-directives:
+//
+//	%D        date, 2006-01-02
+//	%T        time, 15:04:05
+//	%L        level
+//	%M        message
+//	%S        caller, file:line
+//	%P        pid
+//	%F{key}   the named field from the event
+//	%%        literal percent sign
+//
+// The pattern is parsed once, at construction, into a slice of compiled
+// segments so Format only iterates and writes - it never re-parses the
+// pattern on the hot path.
+type PatternFormatter struct {
+	segments []patternDirective
+
+	// mu guards the format-second cache below, reused by %D/%T so that
+	// repeated log calls within the same wall-clock second don't
+	// re-render identical date/time strings.
+	mu         sync.Mutex
+	cachedUnix int64
+	cachedDate string
+	cachedTime string
+}
+
+// NewPatternFormatter compiles pattern into a PatternFormatter. It
+// returns an error if pattern contains an unknown directive or an
+// unterminated %F{...} field reference.
+func NewPatternFormatter(pattern string) (*PatternFormatter, error) {
+	p := &PatternFormatter{cachedUnix: -1}
+
+	segments, err := p.compile(pattern)
+	if err != nil {
+		return nil, err
+	}
+	p.segments = segments
+
+	return p, nil
+}
+
+// Implements Formatter.Format
+func (p *PatternFormatter) Format(w io.Writer, l *Logger, e *Event, msg string, entryTime time.Time) error {
+	for _, seg := range p.segments {
+		seg(w, l, e, msg, entryTime)
+	}
+
+	return nil
+}
+
+func (p *PatternFormatter) compile(pattern string) ([]patternDirective, error) {
+	var segments []patternDirective
+	var literal strings.Builder
+
+	flushLiteral := func() {
+		if literal.Len() == 0 {
+			return
+		}
+		s := literal.String()
+		segments = append(segments, func(w io.Writer, l *Logger, e *Event, msg string, t time.Time) {
+			writeString(w, s)
+		})
+		literal.Reset()
+	}
+
+	runes := []rune(pattern)
+	for i := 0; i < len(runes); i++ {
+		if runes[i] != '%' {
+			literal.WriteRune(runes[i])
+			continue
+		}
+
+		if i+1 >= len(runes) {
+			return nil, fmt.Errorf("wlog: pattern ends with a dangling %%%%")
+		}
+
+		directive := runes[i+1]
+		switch directive {
+		case '%':
+			literal.WriteRune('%')
+			i++
+		case 'D':
+			flushLiteral()
+			segments = append(segments, p.formatDate)
+			i++
+		case 'T':
+			flushLiteral()
+			segments = append(segments, p.formatTime)
+			i++
+		case 'L':
+			flushLiteral()
+			segments = append(segments, formatPatternLevel)
+			i++
+		case 'M':
+			flushLiteral()
+			segments = append(segments, formatPatternMessage)
+			i++
+		case 'S':
+			flushLiteral()
+			segments = append(segments, formatPatternCaller)
+			i++
+		case 'P':
+			flushLiteral()
+			segments = append(segments, formatPatternPid)
+			i++
+		case 'F':
+			if i+2 >= len(runes) || runes[i+2] != '{' {
+				return nil, fmt.Errorf("wlog: %%F directive must be followed by {key}")
+			}
+			end := indexRune(runes, i+3, '}')
+			if end == -1 {
+				return nil, fmt.Errorf("wlog: unterminated %%F{...} directive")
+			}
+			key := string(runes[i+3 : end])
+			flushLiteral()
+			segments = append(segments, formatPatternField(key))
+			i = end
+		default:
+			return nil, fmt.Errorf("wlog: unknown pattern directive %%%c", directive)
+		}
+	}
+
+	flushLiteral()
+
+	return segments, nil
+}
+
+func indexRune(runes []rune, from int, target rune) int {
+	for i := from; i < len(runes); i++ {
+		if runes[i] == target {
+			return i
+		}
+	}
+	return -1
+}
+
+// formatDate and formatTime share a single cache entry keyed by the
+// entry's Unix second: under high log volume, consecutive entries
+// landing in the same second reuse the previously rendered strings
+// instead of calling time.Format again.
+func (p *PatternFormatter) formatDate(w io.Writer, l *Logger, e *Event, msg string, t time.Time) {
+	date, _ := p.cachedStrings(t)
+	writeString(w, date)
+}
+
+func (p *PatternFormatter) formatTime(w io.Writer, l *Logger, e *Event, msg string, t time.Time) {
+	_, clock := p.cachedStrings(t)
+	writeString(w, clock)
+}
+
+func (p *PatternFormatter) cachedStrings(t time.Time) (date, clock string) {
+	unix := t.Unix()
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if unix == p.cachedUnix {
+		return p.cachedDate, p.cachedTime
+	}
+
+	p.cachedUnix = unix
+	p.cachedDate = t.Format("2006-01-02")
+	p.cachedTime = t.Format("15:04:05")
+
+	return p.cachedDate, p.cachedTime
+}
+
+func formatPatternLevel(w io.Writer, l *Logger, e *Event, msg string, t time.Time) {
+	writeString(w, e.Level().String())
+}
+
+func formatPatternMessage(w io.Writer, l *Logger, e *Event, msg string, t time.Time) {
+	writeString(w, msg)
+}
+
+func formatPatternPid(w io.Writer, l *Logger, e *Event, msg string, t time.Time) {
+	itoa(w, os.Getpid(), -1)
+}
+
+func formatPatternCaller(w io.Writer, l *Logger, e *Event, msg string, t time.Time) {
+	_, file, line, ok := runtime.Caller(patternSkipDepth)
+	if !ok {
+		writeString(w, "???:0")
+		return
+	}
+
+	writeString(w, fmt.Sprintf("%s:%d", shortFile(file), line))
+}
+
+func formatPatternField(key string) patternDirective {
+	return func(w io.Writer, l *Logger, e *Event, msg string, t time.Time) {
+		v, ok := e.Fields()[key]
+		if !ok {
+			return
+		}
+		writeString(w, fmt.Sprintf("%v", v))
+	}
+}
+
+func shortFile(file string) string {
+	for i := len(file) - 1; i > 0; i-- {
+		if file[i] == '/' {
+			return file[i+1:]
+		}
+	}
+	return file
+}
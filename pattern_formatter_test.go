@@ -0,0 +1,111 @@
+package wlog
+
+import (
+	"bytes"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPatternFormatterDirectives(t *testing.T) {
+	p, err := NewPatternFormatter("%D %T %L %M %F{user} 100%%")
+	if err != nil {
+		t.Fatalf("NewPatternFormatter returned error: %v", err)
+	}
+
+	var buf bytes.Buffer
+	e := &Event{fields: map[string]interface{}{"user": "alice"}, level: Wrn}
+	entryTime := time.Date(2026, 7, 26, 1, 2, 3, 0, time.UTC)
+
+	if err := p.Format(&buf, &Logger{}, e, "hello", entryTime); err != nil {
+		t.Fatalf("Format returned error: %v", err)
+	}
+
+	want := "2026-07-26 01:02:03 WRN hello alice 100%"
+	if buf.String() != want {
+		t.Errorf("Format = %q, want %q", buf.String(), want)
+	}
+}
+
+// TestPatternFormatterCallerPinsRealCallSite drives %S through a real
+// Logger.Info call (Logger.Info -> Event.log -> Logger.write ->
+// PatternFormatter.Format -> formatPatternCaller) and asserts it
+// resolves to the exact line below, not some fixed frame inside wlog.
+func TestPatternFormatterCallerPinsRealCallSite(t *testing.T) {
+	p, err := NewPatternFormatter("%S")
+	if err != nil {
+		t.Fatalf("NewPatternFormatter returned error: %v", err)
+	}
+
+	var buf bytes.Buffer
+	l := New(&buf).SetFormatter(p)
+
+	l.Info("hello") // <-- wantLine must match this line number
+	wantLine := 44
+
+	want := "pattern_formatter_test.go:" + strconv.Itoa(wantLine)
+	if !strings.Contains(buf.String(), want) {
+		t.Errorf("Format output %q does not contain caller %q", buf.String(), want)
+	}
+}
+
+// TestPatternFormatterCallerPinsRealCallSiteChained is the chained
+// event-builder counterpart to TestPatternFormatterCallerPinsRealCallSite:
+// it drives l.Str(...).Info(msg) instead of the bare l.Info(msg) form, the
+// other call depth patternSkipDepth must resolve correctly.
+func TestPatternFormatterCallerPinsRealCallSiteChained(t *testing.T) {
+	p, err := NewPatternFormatter("%S")
+	if err != nil {
+		t.Fatalf("NewPatternFormatter returned error: %v", err)
+	}
+
+	var buf bytes.Buffer
+	l := New(&buf).SetFormatter(p)
+
+	l.Str("k", "v").Info("hello") // <-- wantLine must match this line number
+	wantLine := 66
+
+	want := "pattern_formatter_test.go:" + strconv.Itoa(wantLine)
+	if !strings.Contains(buf.String(), want) {
+		t.Errorf("Format output %q does not contain caller %q", buf.String(), want)
+	}
+}
+
+func TestPatternFormatterUnknownDirective(t *testing.T) {
+	if _, err := NewPatternFormatter("%Z"); err == nil {
+		t.Error("NewPatternFormatter with an unknown directive should return an error")
+	}
+}
+
+func TestPatternFormatterUnterminatedField(t *testing.T) {
+	if _, err := NewPatternFormatter("%F{user"); err == nil {
+		t.Error("NewPatternFormatter with an unterminated field directive should return an error")
+	}
+}
+
+func TestPatternFormatterCachesWithinSameSecond(t *testing.T) {
+	p, err := NewPatternFormatter("%T")
+	if err != nil {
+		t.Fatalf("NewPatternFormatter returned error: %v", err)
+	}
+
+	e := &Event{fields: map[string]interface{}{}, level: Nfo}
+	t1 := time.Date(2026, 7, 26, 1, 2, 3, 0, time.UTC)
+	t2 := t1.Add(500 * time.Millisecond)
+
+	var buf1, buf2 bytes.Buffer
+	if err := p.Format(&buf1, &Logger{}, e, "a", t1); err != nil {
+		t.Fatalf("Format returned error: %v", err)
+	}
+	if err := p.Format(&buf2, &Logger{}, e, "b", t2); err != nil {
+		t.Fatalf("Format returned error: %v", err)
+	}
+
+	if buf1.String() != buf2.String() {
+		t.Errorf("entries within the same wall-clock second rendered differently: %q vs %q", buf1.String(), buf2.String())
+	}
+	if p.cachedUnix != t1.Unix() {
+		t.Errorf("cachedUnix = %d, want %d", p.cachedUnix, t1.Unix())
+	}
+}